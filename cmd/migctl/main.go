@@ -0,0 +1,228 @@
+// Command migctl is a small clusterctl-style CLI for driving
+// capi-migration-controller migrations imperatively. It reads the
+// Migration* conditions the controller publishes on the CAPI Cluster and can
+// pause, resume or undo an in-progress migration.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	kubeadm "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/giantswarm/capi-migration-controller/pkg/migration"
+)
+
+var rollupConditions = []capi.ConditionType{
+	migration.MigrationEncryptionConfigReady,
+	migration.MigrationControlPlaneReady,
+	migration.MigrationWorkersReady,
+	migration.MigrationCutoverComplete,
+}
+
+func main() {
+	root := &cobra.Command{
+		Use:   "migctl",
+		Short: "Drive capi-migration-controller migrations",
+	}
+
+	root.AddCommand(newStatusCmd())
+	root.AddCommand(newPauseCmd())
+	root.AddCommand(newResumeCmd())
+	root.AddCommand(newUndoCmd())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <cluster-name>",
+		Short: "Print migration rollout status for a cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			cluster, err := getCluster(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+
+			for _, conditionType := range rollupConditions {
+				cond := getCondition(cluster, conditionType)
+				fmt.Printf("%-30s %s\n", conditionType, formatCondition(cond))
+			}
+
+			fmt.Printf("%-30s %s\n", "Ready", formatCondition(getCondition(cluster, capi.ReadyCondition)))
+			fmt.Printf("%-30s %t\n", "Paused", cluster.Annotations[migration.PausedAnnotation] == "true")
+
+			return nil
+		},
+	}
+}
+
+func newPauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause <cluster-name>",
+		Short: "Pause an in-progress migration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setPaused(cmd.Context(), args[0], true)
+		},
+	}
+}
+
+func newResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <cluster-name>",
+		Short: "Resume a paused migration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setPaused(cmd.Context(), args[0], false)
+		},
+	}
+}
+
+func newUndoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo <cluster-name>",
+		Short: "Roll back a botched cutover, deleting the rendered control plane and workers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			clusterName := args[0]
+
+			if err := setPaused(ctx, clusterName, true); err != nil {
+				return err
+			}
+
+			if err := deleteRenderedCAPIObjects(ctx, clusterName); err != nil {
+				return err
+			}
+
+			fmt.Println("Migration paused and the rendered KubeadmControlPlane/MachineDeployment were " +
+				"deleted. The legacy AzureConfig was left in place and remains authoritative; fix whatever " +
+				"broke the migration plan and run 'migctl resume' to try again.")
+			return nil
+		},
+	}
+}
+
+// deleteRenderedCAPIObjects removes the KubeadmControlPlane and
+// MachineDeployment a migration rendered for clusterName, so the legacy
+// giantswarm-operator managed control plane stays authoritative until the
+// migration is retried.
+func deleteRenderedCAPIObjects(ctx context.Context, clusterName string) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	kcp := &kubeadm.KubeadmControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: clusterName}}
+	if err := c.Delete(ctx, kcp); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting KubeadmControlPlane for cluster %q: %w", clusterName, err)
+	}
+
+	md := &capi.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: clusterName}}
+	if err := c.Delete(ctx, md); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting MachineDeployment for cluster %q: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+func setPaused(ctx context.Context, clusterName string, paused bool) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := getCluster(ctx, c, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[migration.PausedAnnotation] = "true"
+	} else {
+		delete(cluster.Annotations, migration.PausedAnnotation)
+	}
+
+	if err := c.Update(ctx, cluster); err != nil {
+		return fmt.Errorf("updating cluster %q: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+func getCluster(ctx context.Context, c client.Client, name string) (*capi.Cluster, error) {
+	cluster := &capi.Cluster{}
+	key := client.ObjectKey{Namespace: "default", Name: name}
+	if err := c.Get(ctx, key, cluster); err != nil {
+		return nil, fmt.Errorf("getting cluster %q: %w", name, err)
+	}
+
+	return cluster, nil
+}
+
+func getCondition(cluster *capi.Cluster, conditionType capi.ConditionType) *capi.Condition {
+	for i := range cluster.Status.Conditions {
+		if cluster.Status.Conditions[i].Type == conditionType {
+			return &cluster.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func formatCondition(cond *capi.Condition) string {
+	if cond == nil {
+		return "Unknown"
+	}
+
+	if cond.Status == "True" {
+		return "True"
+	}
+
+	return fmt.Sprintf("False (%s: %s)", cond.Reason, cond.Message)
+}
+
+func newClient() (client.Client, error) {
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := capi.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := kubeadm.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("building kube client: %w", err)
+	}
+
+	return c, nil
+}
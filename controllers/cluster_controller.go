@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -29,10 +30,18 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	capiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/giantswarm/capi-migration-controller/pkg/migration"
 )
 
+// finalizerName keeps the CAPI Cluster around across the several reconcile
+// loops ReconcileDelete needs to drain the legacy master nodes; without it
+// Kubernetes would finish deleting the Cluster before the drain completes.
+const finalizerName = "migration.giantswarm.io/capi-migration-controller"
+
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
 	client.Client
@@ -61,8 +70,18 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, microerror.Mask(err)
 	}
 
+	if cluster.Annotations[migration.PausedAnnotation] == "true" {
+		r.Log.Debugf(ctx, "migration is paused via migctl, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
 	// Based on https://github.com/kubernetes-sigs/cluster-api/blob/master/controllers/machine_controller.go.
 	if !cluster.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(cluster, finalizerName) {
+			// Nothing left to drain, or cutover was never started.
+			return ctrl.Result{}, nil
+		}
+
 		res, err := r.reconcileDelete(ctx, cluster)
 		if err != nil {
 			requeueAfter := 30 * time.Second
@@ -70,9 +89,23 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 
+		if res.IsZero() {
+			controllerutil.RemoveFinalizer(cluster, finalizerName)
+			if err := r.Update(ctx, cluster); err != nil {
+				return ctrl.Result{}, microerror.Mask(err)
+			}
+		}
+
 		return res, nil
 	}
 
+	if !controllerutil.ContainsFinalizer(cluster, finalizerName) {
+		controllerutil.AddFinalizer(cluster, finalizerName)
+		if err := r.Update(ctx, cluster); err != nil {
+			return ctrl.Result{}, microerror.Mask(err)
+		}
+	}
+
 	res, err := r.reconcile(ctx, cluster)
 	if err != nil {
 		requeueAfter := 30 * time.Second
@@ -91,10 +124,93 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *capiv1alpha3.Cluster) (ctrl.Result, error) {
 	r.Log.Debugf(ctx, "calling reconcile")
+
+	m, err := r.newMigrator(cluster)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	err = m.ReadSourceCRs(ctx)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	if mv, ok := m.(migratorWithValidate); ok {
+		if err := mv.Validate(ctx); err != nil {
+			requeueAfter := 30 * time.Second
+			r.Log.Errorf(ctx, err, "preflight validation failed, requeuing after %#q", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	err = m.RenderInfra(ctx)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	if cpr, ok := m.(migration.ControlPlaneRenderer); ok {
+		if err := cpr.RenderControlPlane(ctx); err != nil {
+			return ctrl.Result{}, microerror.Mask(err)
+		}
+	} else {
+		r.Log.Debugf(ctx, "migrator for %q does not support rendering a control plane yet", cluster.Name)
+	}
+
+	if wr, ok := m.(migration.WorkersRenderer); ok {
+		if err := wr.RenderWorkers(ctx); err != nil {
+			return ctrl.Result{}, microerror.Mask(err)
+		}
+	} else {
+		r.Log.Debugf(ctx, "migrator for %q does not support rendering workers yet", cluster.Name)
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// migratorWithDelete is implemented by Migrator implementations that support
+// draining and removing the legacy control plane as part of cutover.
+type migratorWithDelete interface {
+	ReconcileDelete(ctx context.Context, cluster *capiv1alpha3.Cluster) (ctrl.Result, error)
+}
+
+// migratorWithValidate is implemented by Migrator implementations that
+// support pre-flight validation of the rendered migration plan.
+type migratorWithValidate interface {
+	Validate(ctx context.Context) error
+}
+
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *capiv1alpha3.Cluster) (ctrl.Result, error) {
 	r.Log.Debugf(ctx, "calling reconcileDelete")
-	return ctrl.Result{}, nil
+
+	m, err := r.newMigrator(cluster)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	md, ok := m.(migratorWithDelete)
+	if !ok {
+		r.Log.Debugf(ctx, "migrator for %q does not support cutover deletion yet", cluster.Name)
+		return ctrl.Result{}, nil
+	}
+
+	return md.ReconcileDelete(ctx, cluster)
+}
+
+// newMigrator picks a migration.Migrator implementation based on the Kind of
+// the infrastructureRef set on the CAPI Cluster.
+func (r *ClusterReconciler) newMigrator(cluster *capiv1alpha3.Cluster) (migration.Migrator, error) {
+	if cluster.Spec.InfrastructureRef == nil {
+		return nil, microerror.Mask(fmt.Errorf("cluster %q has no infrastructureRef set", cluster.Name))
+	}
+
+	switch cluster.Spec.InfrastructureRef.Kind {
+	case "AzureCluster":
+		return migration.NewAzureMigrator(cluster.Name, r.Client, r.VaultClient, r.Scheme), nil
+	case "AWSCluster":
+		return migration.NewAWSMigrator(cluster.Name, r.Client), nil
+	case "VSphereCluster":
+		return migration.NewVSphereMigrator(cluster.Name, r.Client), nil
+	default:
+		return nil, microerror.Mask(fmt.Errorf("unsupported infrastructureRef kind %q", cluster.Spec.InfrastructureRef.Kind))
+	}
 }
@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// fieldManager is the field manager used for every server-side apply this
+// controller performs, so it can tell its own fields apart from ones set by
+// other controllers (e.g. cluster-api-provider-azure) on the same object.
+const fieldManager = "capi-migration-controller"
+
+// apply server-side applies obj, force-taking ownership of the fields this
+// controller sets, and sets owner as its controller owner reference. Unlike
+// the Create-and-swallow-AlreadyExists pattern it replaces, this lets
+// changes to the rendered templates (e.g. a K8sVersion bump) reconcile into
+// objects that already exist, and lets Kubernetes garbage collect the
+// rendered objects once the owning AzureConfig is deleted.
+func (m *azureMigrator) apply(ctx context.Context, obj ctrl.Object, owner ctrl.Object) error {
+	if err := controllerutil.SetControllerReference(owner, obj, m.scheme); err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Unlike Create/Update, Patch doesn't consult the scheme to fill in
+	// apiVersion/kind for us, and server-side apply rejects a patch body
+	// that's missing them. Stamp the object's TypeMeta before sending it.
+	gvk, err := apiutil.GVKForObject(obj, m.scheme)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	err = m.mcCtrlClient.Patch(ctx, obj, ctrl.Apply, ctrl.ForceOwnership, ctrl.FieldOwner(fieldManager))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// owningAzureConfig returns the source AzureConfig read by ReadSourceCRs, to
+// be used as the owner reference for every rendered CAPI object.
+func (m *azureMigrator) owningAzureConfig() (ctrl.Object, error) {
+	obj, exists := m.crs["AzureConfig"]
+	if !exists {
+		return nil, microerror.Mask(fmt.Errorf("AzureConfig not read yet for %q", m.clusterID))
+	}
+
+	owner, ok := obj.(ctrl.Object)
+	if !ok {
+		return nil, microerror.Mask(fmt.Errorf("can't convert obj (%T) to %T", obj, owner))
+	}
+
+	return owner, nil
+}
@@ -0,0 +1,118 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	provider "github.com/giantswarm/apiextensions/v3/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// awsMigrator migrates a giantswarm-operator managed AWS cluster (backed by
+// an AWSConfig) to Cluster API resources reconciled by
+// cluster-api-provider-aws.
+type awsMigrator struct {
+	clusterID    string
+	mcCtrlClient ctrl.Client
+
+	crs map[string]runtime.Object
+}
+
+// NewAWSMigrator returns a Migrator for clusters backed by
+// cluster-api-provider-aws.
+func NewAWSMigrator(clusterID string, mcCtrlClient ctrl.Client) Migrator {
+	return &awsMigrator{
+		clusterID:    clusterID,
+		mcCtrlClient: mcCtrlClient,
+		crs:          map[string]runtime.Object{},
+	}
+}
+
+func (m *awsMigrator) ReadSourceCRs(ctx context.Context) error {
+	objList := &provider.AWSConfigList{}
+	selector := ctrl.MatchingLabels{capi.ClusterLabelName: m.clusterID}
+	err := m.mcCtrlClient.List(ctx, objList, selector)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(objList.Items) == 0 {
+		return microerror.Mask(fmt.Errorf("AWSConfig not found for %q", m.clusterID))
+	}
+	if len(objList.Items) > 1 {
+		return microerror.Mask(fmt.Errorf("more than one AWSConfig for cluster ID %q", m.clusterID))
+	}
+
+	m.crs[objList.Items[0].Kind] = &objList.Items[0]
+
+	return nil
+}
+
+func (m *awsMigrator) RenderInfra(ctx context.Context) error {
+	awsConfig, err := m.awsConfig()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	cluster := &capa.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.clusterID,
+			Namespace: "default",
+		},
+		Spec: capa.AWSClusterSpec{
+			Region: awsConfig.Spec.Provider.Region,
+		},
+	}
+
+	err = m.mcCtrlClient.Create(ctx, cluster)
+	if apierrors.IsAlreadyExists(err) {
+		// It's ok. It's already there.
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	machineTemplate := &capa.AWSMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.clusterID,
+			Namespace: "default",
+		},
+	}
+
+	err = m.mcCtrlClient.Create(ctx, machineTemplate)
+	if apierrors.IsAlreadyExists(err) {
+		// It's ok. It's already there.
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// RenderControlPlane and RenderWorkers are intentionally not implemented
+// yet: awsMigrator doesn't satisfy migration.ControlPlaneRenderer or
+// migration.WorkersRenderer, so the reconciler skips those phases for AWS
+// clusters instead of failing every loop.
+
+func (m *awsMigrator) Cutover(ctx context.Context) error {
+	return nil
+}
+
+func (m *awsMigrator) awsConfig() (*provider.AWSConfig, error) {
+	obj, exists := m.crs["AWSConfig"]
+	if !exists {
+		return nil, microerror.Mask(fmt.Errorf("AWSConfig not read yet for %q", m.clusterID))
+	}
+
+	awsConfig, ok := obj.(*provider.AWSConfig)
+	if !ok {
+		return nil, microerror.Mask(fmt.Errorf("can't convert obj (%T) to %T", obj, awsConfig))
+	}
+
+	return awsConfig, nil
+}
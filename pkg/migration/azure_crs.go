@@ -13,7 +13,6 @@ import (
 	"github.com/giantswarm/apiextensions/v3/pkg/label"
 	"github.com/giantswarm/microerror"
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	capz "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	capzexp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
@@ -25,25 +24,10 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-const (
-	EncryptionSecret = "EncryptionSecret"
-)
-
-func (m *azureMigrator) createEncryptionConfigSecret(ctx context.Context) error {
-	var origEncryptionSecret *corev1.Secret
-	{
-		obj, exists := m.crs[EncryptionSecret]
-		if !exists {
-			return microerror.Mask(fmt.Errorf("encryption secret not found"))
-		}
-
-		origEncryptionSecret, ok := obj.(*corev1.Secret)
-		if !ok {
-			return microerror.Mask(fmt.Errorf("can't convert obj (%T) to %T", obj, origEncryptionSecret))
-		}
-	}
-
-	encryptionConfigTmpl := `
+// encryptionConfigTmpl is rendered with one or more aescbc key blocks, head
+// key first. The head key is used for writes; the rest are kept so Secrets
+// encrypted under an older key can still be decrypted during rotation.
+const encryptionConfigTmpl = `
 kind: EncryptionConfiguration
 apiVersion: apiserver.config.k8s.io/v1
 resources:
@@ -52,16 +36,25 @@ resources:
     providers:
     - aescbc:
         keys:
-        - name: key1
-          secret: %s
+%s
     - identity: {}`
 
-	renderedConfig := fmt.Sprintf(encryptionConfigTmpl, origEncryptionSecret.Data["encryption"])
+func (m *azureMigrator) createEncryptionConfigSecret(ctx context.Context) error {
+	keys, err := m.readEncryptionKeysFromVault(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	renderedConfig, annotations, err := renderEncryptionConfig(keys)
+	if err != nil {
+		return microerror.Mask(err)
+	}
 
 	s := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-k8s-encryption-config", m.clusterID),
-			Namespace: "default",
+			Name:        fmt.Sprintf("%s-k8s-encryption-config", m.clusterID),
+			Namespace:   "default",
+			Annotations: annotations,
 		},
 		Type: corev1.SecretTypeOpaque,
 		StringData: map[string]string{
@@ -69,14 +62,12 @@ resources:
 		},
 	}
 
-	err := m.mcCtrlClient.Create(ctx, s)
-	if apierrors.IsAlreadyExists(err) {
-		// It's fine. No worries.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, s, owner))
 }
 
 func (m *azureMigrator) createProxyConfigSecret(ctx context.Context) error {
@@ -98,14 +89,12 @@ metricsBindAddress: 0.0.0.0:10249`
 			"proxy": proxyConfig,
 		},
 	}
-	err := m.mcCtrlClient.Create(ctx, s)
-	if apierrors.IsAlreadyExists(err) {
-		// It's fine. No worries.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, s, owner))
 }
 
 func (m *azureMigrator) createKubeadmControlPlane(ctx context.Context) error {
@@ -165,14 +154,12 @@ func (m *azureMigrator) createKubeadmControlPlane(ctx context.Context) error {
 		return microerror.Mask(err)
 	}
 
-	err = m.mcCtrlClient.Create(ctx, kcp)
-	if apierrors.IsAlreadyExists(err) {
-		// It's ok. It's already there.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, kcp, owner))
 }
 
 func (m *azureMigrator) createMasterAzureMachineTemplate(ctx context.Context) error {
@@ -199,14 +186,12 @@ func (m *azureMigrator) createMasterAzureMachineTemplate(ctx context.Context) er
 		return microerror.Mask(err)
 	}
 
-	err = m.mcCtrlClient.Create(ctx, amt)
-	if apierrors.IsAlreadyExists(err) {
-		// It's ok. It's already there.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, amt, owner))
 }
 
 func (m *azureMigrator) createWorkersKubeadmConfigTemplate(ctx context.Context) error {
@@ -233,14 +218,12 @@ func (m *azureMigrator) createWorkersKubeadmConfigTemplate(ctx context.Context)
 		return microerror.Mask(err)
 	}
 
-	err = m.mcCtrlClient.Create(ctx, kct)
-	if apierrors.IsAlreadyExists(err) {
-		// It's ok. It's already there.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, kct, owner))
 }
 
 func (m *azureMigrator) createWorkersAzureMachineTemplate(ctx context.Context) error {
@@ -267,17 +250,35 @@ func (m *azureMigrator) createWorkersAzureMachineTemplate(ctx context.Context) e
 		return microerror.Mask(err)
 	}
 
-	err = m.mcCtrlClient.Create(ctx, amt)
-	if apierrors.IsAlreadyExists(err) {
-		// It's ok. It's already there.
-	} else if err != nil {
+	owner, err := m.owningAzureConfig()
+	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	return nil
+	return microerror.Mask(m.apply(ctx, amt, owner))
 }
 
 func (m *azureMigrator) createWorkersMachineDeployment(ctx context.Context) error {
+	var cluster *capz.AzureCluster
+	{
+		obj, found := m.crs["AzureCluster"]
+		if !found {
+			return microerror.Mask(fmt.Errorf("AzureCluster not found"))
+		}
+
+		c, ok := obj.(*capz.AzureCluster)
+		if !ok {
+			return microerror.Mask(fmt.Errorf("can't cast obj (%T) to %T", obj, c))
+		}
+
+		cluster = c
+	}
+
+	releaseComponents, err := m.getReleaseComponents(ctx, cluster.GetLabels()[label.ReleaseVersion])
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	tmpl, err := template.ParseFS(templatesFS, "templates/workers_machine_deployment.yaml.tmpl")
 	if err != nil {
 		return microerror.Mask(err)
@@ -288,7 +289,7 @@ func (m *azureMigrator) createWorkersMachineDeployment(ctx context.Context) erro
 		K8sVersion string
 	}{
 		ClusterID:  m.clusterID,
-		K8sVersion: "v1.19.9",
+		K8sVersion: releaseComponents["kubernetes"],
 	}
 
 	buf := bytes.NewBuffer(nil)
@@ -303,27 +304,12 @@ func (m *azureMigrator) createWorkersMachineDeployment(ctx context.Context) erro
 		return microerror.Mask(err)
 	}
 
-	err = m.mcCtrlClient.Create(ctx, md)
-	if apierrors.IsAlreadyExists(err) {
-		// It's ok. It's already there.
-	} else if err != nil {
-		return microerror.Mask(err)
-	}
-
-	return nil
-}
-
-func (m *azureMigrator) readEncryptionSecret(ctx context.Context) error {
-	obj := &corev1.Secret{}
-	key := ctrl.ObjectKey{Namespace: "default", Name: fmt.Sprintf("%s-encryption", m.clusterID)}
-	err := m.mcCtrlClient.Get(ctx, key, obj)
+	owner, err := m.owningAzureConfig()
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	m.crs[EncryptionSecret] = obj
-
-	return nil
+	return microerror.Mask(m.apply(ctx, md, owner))
 }
 
 func (m *azureMigrator) readAzureConfig(ctx context.Context) error {
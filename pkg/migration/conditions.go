@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// PausedAnnotation pauses this controller's reconciliation of a single
+// Cluster when set to "true". It is scoped to capi-migration-controller
+// only: unlike Cluster.Spec.Paused, setting it does not also pause the
+// already-adopted CAPI control plane/worker controllers managing the
+// cluster post-cutover.
+const PausedAnnotation = "migration.giantswarm.io/paused"
+
+// Condition types published on the CAPI Cluster so operators (and migctl)
+// can follow migration progress without reading controller logs. Ready is
+// the summary condition aggregated from the ones below it.
+const (
+	MigrationEncryptionConfigReady capi.ConditionType = "MigrationEncryptionConfigReady"
+	MigrationControlPlaneReady     capi.ConditionType = "MigrationControlPlaneReady"
+	MigrationWorkersReady          capi.ConditionType = "MigrationWorkersReady"
+	MigrationCutoverComplete       capi.ConditionType = "MigrationCutoverComplete"
+)
+
+// cluster returns the CAPI Cluster read by ReadSourceCRs.
+func (m *azureMigrator) cluster() (*capi.Cluster, error) {
+	obj, exists := m.crs["Cluster"]
+	if !exists {
+		return nil, microerror.Mask(fmt.Errorf("Cluster not read yet for %q", m.clusterID))
+	}
+
+	cluster, ok := obj.(*capi.Cluster)
+	if !ok {
+		return nil, microerror.Mask(fmt.Errorf("can't convert obj (%T) to %T", obj, cluster))
+	}
+
+	return cluster, nil
+}
+
+// runPhase runs step, marks conditionType true or false on the Cluster read
+// by ReadSourceCRs depending on the outcome, recomputes the aggregated Ready
+// condition, and updates the Cluster status. Reconcile phases should always
+// go through this so rollout status stays accurate even on partial failure.
+func (m *azureMigrator) runPhase(ctx context.Context, conditionType capi.ConditionType, step func(context.Context) error) error {
+	cluster, err := m.cluster()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	stepErr := step(ctx)
+	if stepErr != nil {
+		conditions.MarkFalse(cluster, conditionType, "PhaseFailed", capi.ConditionSeverityWarning, stepErr.Error())
+	} else {
+		conditions.MarkTrue(cluster, conditionType)
+	}
+
+	conditions.SetSummary(cluster, conditions.WithConditions(
+		MigrationEncryptionConfigReady,
+		MigrationControlPlaneReady,
+		MigrationWorkersReady,
+		MigrationCutoverComplete,
+	))
+
+	if patchErr := m.mcCtrlClient.Status().Update(ctx, cluster); patchErr != nil {
+		return microerror.Mask(patchErr)
+	}
+
+	return microerror.Mask(stepErr)
+}
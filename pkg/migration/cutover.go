@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// legacyMasterLabelSelector matches the master nodes giantswarm-operator
+// labels on Azure, as opposed to the ones newly joined by the
+// KubeadmControlPlane.
+const legacyMasterLabelSelector = "role=master"
+
+// ReconcileDelete cordons and drains the legacy giantswarm-operator managed
+// master nodes before the caller removes the source AzureConfig. Callers
+// must keep invoking it until it returns a zero Result, since draining a
+// node can take several reconcile loops.
+func (m *azureMigrator) ReconcileDelete(ctx context.Context, cluster *capi.Cluster) (ctrl.Result, error) {
+	kubeClient, err := m.workloadClusterKubeClient(ctx)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	nodeList, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: legacyMasterLabelSelector})
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	var nodes []*corev1.Node
+	for i := range nodeList.Items {
+		nodes = append(nodes, &nodeList.Items[i])
+	}
+
+	res, drainErr := m.drainLegacyMasters(ctx, cluster, kubeClient, nodes)
+
+	if drainErr == nil && res.IsZero() {
+		conditions.MarkTrue(cluster, MigrationCutoverComplete)
+	}
+
+	// Persist whatever drainLegacyMasters set on DrainingSucceeded (and
+	// MigrationCutoverComplete above) on every pass, not just the terminal
+	// one, so a stuck or failing drain is visible to operators and migctl
+	// while it's still in progress.
+	if err := m.mcCtrlClient.Status().Update(ctx, cluster); err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
+	}
+
+	if drainErr != nil {
+		return ctrl.Result{}, microerror.Mask(drainErr)
+	}
+
+	return res, nil
+}
+
+// workloadClusterKubeClient builds a client for the tenant cluster from the
+// kubeconfig Secret giantswarm-operator writes to the management cluster.
+func (m *azureMigrator) workloadClusterKubeClient(ctx context.Context) (kubernetes.Interface, error) {
+	secret := &corev1.Secret{}
+	key := ctrlclient.ObjectKey{Namespace: "default", Name: fmt.Sprintf("%s-kubeconfig", m.clusterID)}
+	err := m.mcCtrlClient.Get(ctx, key, secret)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return kubeClient, nil
+}
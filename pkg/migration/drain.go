@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	kubedrain "sigs.k8s.io/cluster-api/third_party/kubernetes-drain"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// DrainingSucceeded is set on the CAPI Cluster once every legacy master
+	// node has been cordoned and drained.
+	DrainingSucceeded capi.ConditionType = "DrainingSucceeded"
+
+	// NodeDrainTimeoutAnnotation overrides defaultNodeDrainTimeout for a
+	// single cluster.
+	NodeDrainTimeoutAnnotation = "migration.giantswarm.io/node-drain-timeout"
+
+	// DrainGracePeriodAnnotation overrides defaultDrainGracePeriod for a
+	// single cluster.
+	DrainGracePeriodAnnotation = "migration.giantswarm.io/drain-grace-period"
+
+	defaultNodeDrainTimeout = 20 * time.Minute
+	defaultDrainGracePeriod = 10 * time.Second
+	drainRetryInterval      = 20 * time.Second
+)
+
+// drainLegacyMasters cordons and drains the legacy giantswarm-operator
+// managed master nodes ahead of deleting them, mirroring the drain handling
+// in cluster-api's machine controller. It returns a non-zero RequeueAfter
+// while eviction of at least one node is still in progress.
+func (m *azureMigrator) drainLegacyMasters(ctx context.Context, cluster *capi.Cluster, kubeClient kubernetes.Interface, nodes []*corev1.Node) (ctrl.Result, error) {
+	timeout := nodeDrainTimeout(cluster)
+	gracePeriod := drainGracePeriod(cluster)
+
+	for _, node := range nodes {
+		err := kubedrain.Cordon(kubeClient.CoreV1().Nodes(), node)
+		if err != nil {
+			conditions.MarkFalse(cluster, DrainingSucceeded, "CordonFailed", capi.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, microerror.Mask(err)
+		}
+
+		err = kubedrain.Drain(kubeClient, []*corev1.Node{node}, &kubedrain.DrainOptions{
+			IgnoreAllDaemonSets: true,
+			DeleteLocalData:     true,
+			Force:               true,
+			GracePeriodSeconds:  int(gracePeriod.Seconds()),
+			Timeout:             timeout,
+		})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			conditions.MarkFalse(cluster, DrainingSucceeded, "DrainInProgress", capi.ConditionSeverityInfo, "draining node %q: %s", node.Name, err)
+			return ctrl.Result{RequeueAfter: drainRetryInterval}, nil
+		}
+	}
+
+	conditions.MarkTrue(cluster, DrainingSucceeded)
+
+	return ctrl.Result{}, nil
+}
+
+func nodeDrainTimeout(cluster *capi.Cluster) time.Duration {
+	v, ok := cluster.Annotations[NodeDrainTimeoutAnnotation]
+	if !ok {
+		return defaultNodeDrainTimeout
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultNodeDrainTimeout
+	}
+
+	return d
+}
+
+// drainGracePeriod returns the per-pod eviction grace period to use when
+// draining a legacy master node, letting an operator extend it via
+// DrainGracePeriodAnnotation for clusters running workloads that need longer
+// than defaultDrainGracePeriod to shut down cleanly.
+func drainGracePeriod(cluster *capi.Cluster) time.Duration {
+	v, ok := cluster.Annotations[DrainGracePeriodAnnotation]
+	if !ok {
+		return defaultDrainGracePeriod
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultDrainGracePeriod
+	}
+
+	return d
+}
@@ -0,0 +1,77 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeDrainTimeout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    time.Duration
+	}{
+		{
+			name:        "no annotation",
+			annotations: nil,
+			expected:    defaultNodeDrainTimeout,
+		},
+		{
+			name:        "valid override",
+			annotations: map[string]string{NodeDrainTimeoutAnnotation: "5m"},
+			expected:    5 * time.Minute,
+		},
+		{
+			name:        "invalid value falls back to default",
+			annotations: map[string]string{NodeDrainTimeoutAnnotation: "not-a-duration"},
+			expected:    defaultNodeDrainTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &capi.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+
+			if got := nodeDrainTimeout(cluster); got != tc.expected {
+				t.Errorf("nodeDrainTimeout() = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDrainGracePeriod(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    time.Duration
+	}{
+		{
+			name:        "no annotation",
+			annotations: nil,
+			expected:    defaultDrainGracePeriod,
+		},
+		{
+			name:        "valid override",
+			annotations: map[string]string{DrainGracePeriodAnnotation: "30s"},
+			expected:    30 * time.Second,
+		},
+		{
+			name:        "invalid value falls back to default",
+			annotations: map[string]string{DrainGracePeriodAnnotation: "not-a-duration"},
+			expected:    defaultDrainGracePeriod,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &capi.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+
+			if got := drainGracePeriod(cluster); got != tc.expected {
+				t.Errorf("drainGracePeriod() = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// maxActiveEncryptionKeys bounds how many keys in total, the head key
+	// included, we render into the Secret. The head key is always the write
+	// key; the rest are kept around for decryption.
+	maxActiveEncryptionKeys = 2
+
+	encryptionKeyVersionAnnotationPrefix   = "encryption.giantswarm.io/vault-key-version-"
+	encryptionKeyCreatedAtAnnotationPrefix = "encryption.giantswarm.io/vault-key-created-at-"
+)
+
+// vaultEncryptionKey is a single aescbc key read from Vault's KV v2 secret
+// engine, along with its Vault key version and creation time.
+type vaultEncryptionKey struct {
+	Version   int
+	Secret    string
+	CreatedAt time.Time
+}
+
+// readEncryptionKeysFromVault reads every non-destroyed version of the
+// cluster's encryption key from Vault and returns the newest
+// maxActiveEncryptionKeys of them, ordered newest first.
+// The first key in the returned slice is the write key; the rest are kept
+// around so previously encrypted Secrets can still be decrypted.
+func (m *azureMigrator) readEncryptionKeysFromVault(ctx context.Context) ([]vaultEncryptionKey, error) {
+	metaPath := fmt.Sprintf("secret/metadata/capi-migration/%s/encryption", m.clusterID)
+	meta, err := m.vaultClient.Logical().ReadWithContext(ctx, metaPath)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if meta == nil || meta.Data == nil {
+		return nil, microerror.Mask(fmt.Errorf("no encryption key metadata found in vault for %q", m.clusterID))
+	}
+
+	versions, ok := meta.Data["versions"].(map[string]interface{})
+	if !ok {
+		return nil, microerror.Mask(fmt.Errorf("unexpected vault metadata format for %q", m.clusterID))
+	}
+
+	var keys []vaultEncryptionKey
+	for v, raw := range versions {
+		info, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if destroyed, _ := info["destroyed"].(bool); destroyed {
+			continue
+		}
+
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, fmt.Sprintf("%v", info["created_time"]))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		dataPath := fmt.Sprintf("secret/data/capi-migration/%s/encryption", m.clusterID)
+		data, err := m.vaultClient.Logical().ReadWithDataWithContext(ctx, dataPath, map[string][]string{"version": {v}})
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if data == nil || data.Data == nil {
+			continue
+		}
+
+		secretData, ok := data.Data["data"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		secret, ok := secretData["key"].(string)
+		if !ok {
+			return nil, microerror.Mask(fmt.Errorf("vault secret for %q version %d is missing %q", m.clusterID, version, "key"))
+		}
+
+		keys = append(keys, vaultEncryptionKey{Version: version, Secret: secret, CreatedAt: createdAt})
+	}
+
+	if len(keys) == 0 {
+		return nil, microerror.Mask(fmt.Errorf("no usable encryption keys in vault for %q", m.clusterID))
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Version > keys[j].Version })
+
+	if len(keys) > maxActiveEncryptionKeys {
+		keys = keys[:maxActiveEncryptionKeys]
+	}
+
+	return keys, nil
+}
+
+// renderEncryptionConfig renders an EncryptionConfiguration with one aescbc
+// key block per key, head first, and returns the annotations operators can
+// use to tell which Vault key versions are currently baked into the Secret.
+func renderEncryptionConfig(keys []vaultEncryptionKey) (string, map[string]string, error) {
+	if len(keys) == 0 {
+		return "", nil, microerror.Mask(fmt.Errorf("no encryption keys to render"))
+	}
+
+	var keyBlocks strings.Builder
+	annotations := map[string]string{}
+	for i, k := range keys {
+		fmt.Fprintf(&keyBlocks, "        - name: key%d\n          secret: %s\n", k.Version, k.Secret)
+		annotations[fmt.Sprintf("%s%d", encryptionKeyVersionAnnotationPrefix, i)] = strconv.Itoa(k.Version)
+		annotations[fmt.Sprintf("%s%d", encryptionKeyCreatedAtAnnotationPrefix, i)] = k.CreatedAt.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf(encryptionConfigTmpl, strings.TrimRight(keyBlocks.String(), "\n")), annotations, nil
+}
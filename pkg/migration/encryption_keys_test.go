@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderEncryptionConfigNoKeys(t *testing.T) {
+	_, _, err := renderEncryptionConfig(nil)
+	if err == nil {
+		t.Fatal("expected an error for an empty key list, got nil")
+	}
+}
+
+func TestRenderEncryptionConfig(t *testing.T) {
+	createdAt := time.Date(2021, 5, 4, 12, 0, 0, 0, time.UTC)
+	keys := []vaultEncryptionKey{
+		{Version: 2, Secret: "head-secret", CreatedAt: createdAt},
+		{Version: 1, Secret: "old-secret", CreatedAt: createdAt.Add(-time.Hour)},
+	}
+
+	rendered, annotations, err := renderEncryptionConfig(keys)
+	if err != nil {
+		t.Fatalf("renderEncryptionConfig() returned an error: %s", err)
+	}
+
+	if !strings.Contains(rendered, "secret: head-secret") {
+		t.Errorf("rendered config is missing the head key: %s", rendered)
+	}
+	if !strings.Contains(rendered, "secret: old-secret") {
+		t.Errorf("rendered config is missing the old key: %s", rendered)
+	}
+	if strings.Index(rendered, "head-secret") > strings.Index(rendered, "old-secret") {
+		t.Error("expected the head key to be rendered before older keys")
+	}
+
+	if got := annotations[encryptionKeyVersionAnnotationPrefix+"0"]; got != strconv.Itoa(keys[0].Version) {
+		t.Errorf("annotation for head key version = %q, want %q", got, strconv.Itoa(keys[0].Version))
+	}
+	if got := annotations[encryptionKeyVersionAnnotationPrefix+"1"]; got != strconv.Itoa(keys[1].Version) {
+		t.Errorf("annotation for second key version = %q, want %q", got, strconv.Itoa(keys[1].Version))
+	}
+}
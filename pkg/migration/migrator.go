@@ -0,0 +1,137 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	vaultapi "github.com/hashicorp/vault/api"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Migrator drives the migration of a single giantswarm-operator managed
+// cluster to Cluster API resources for a specific infrastructure provider.
+// ClusterReconciler picks an implementation based on the infrastructureRef
+// GVK on the CAPI Cluster being reconciled.
+type Migrator interface {
+	// ReadSourceCRs reads the legacy giantswarm CRs the migration is based
+	// on from the management cluster.
+	ReadSourceCRs(ctx context.Context) error
+	// RenderInfra creates the provider-specific infrastructure resources
+	// the migration needs (e.g. AWSCluster, VSphereCluster). The Azure
+	// implementation renders nothing here.
+	RenderInfra(ctx context.Context) error
+	// Cutover moves traffic from the legacy control plane to the newly
+	// rendered one and tears down the legacy resources.
+	Cutover(ctx context.Context) error
+}
+
+// ControlPlaneRenderer is implemented by Migrator implementations that can
+// render a KubeadmControlPlane and everything it depends on. Providers that
+// don't support this yet are skipped rather than failing every reconcile.
+type ControlPlaneRenderer interface {
+	RenderControlPlane(ctx context.Context) error
+}
+
+// WorkersRenderer is implemented by Migrator implementations that can
+// render a worker MachineDeployment and its templates. Providers that don't
+// support this yet are skipped rather than failing every reconcile.
+type WorkersRenderer interface {
+	RenderWorkers(ctx context.Context) error
+}
+
+// azureMigrator migrates a giantswarm-operator managed Azure cluster to
+// Cluster API resources reconciled by cluster-api-provider-azure.
+type azureMigrator struct {
+	clusterID    string
+	mcCtrlClient ctrl.Client
+	vaultClient  *vaultapi.Client
+	scheme       *runtime.Scheme
+
+	// crs holds the source CRs read from the management cluster, keyed by
+	// their Kind.
+	crs map[string]runtime.Object
+}
+
+// NewAzureMigrator returns a Migrator for clusters backed by
+// cluster-api-provider-azure.
+func NewAzureMigrator(clusterID string, mcCtrlClient ctrl.Client, vaultClient *vaultapi.Client, scheme *runtime.Scheme) Migrator {
+	return &azureMigrator{
+		clusterID:    clusterID,
+		mcCtrlClient: mcCtrlClient,
+		vaultClient:  vaultClient,
+		scheme:       scheme,
+		crs:          map[string]runtime.Object{},
+	}
+}
+
+func (m *azureMigrator) ReadSourceCRs(ctx context.Context) error {
+	steps := []func(context.Context) error{
+		m.readAzureConfig,
+		m.readCluster,
+		m.readAzureCluster,
+		m.readMachinePools,
+		m.readAzureMachinePools,
+	}
+
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (m *azureMigrator) RenderInfra(ctx context.Context) error {
+	// AzureCluster is provisioned by cluster-api-provider-azure from the
+	// infrastructureRef on the CAPI Cluster; there is nothing to render
+	// here ourselves.
+	return nil
+}
+
+func (m *azureMigrator) RenderControlPlane(ctx context.Context) error {
+	err := m.runPhase(ctx, MigrationEncryptionConfigReady, func(ctx context.Context) error {
+		if err := m.createEncryptionConfigSecret(ctx); err != nil {
+			return microerror.Mask(err)
+		}
+		return microerror.Mask(m.createProxyConfigSecret(ctx))
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = m.runPhase(ctx, MigrationControlPlaneReady, func(ctx context.Context) error {
+		if err := m.createKubeadmControlPlane(ctx); err != nil {
+			return microerror.Mask(err)
+		}
+		return microerror.Mask(m.createMasterAzureMachineTemplate(ctx))
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (m *azureMigrator) RenderWorkers(ctx context.Context) error {
+	return m.runPhase(ctx, MigrationWorkersReady, func(ctx context.Context) error {
+		steps := []func(context.Context) error{
+			m.createWorkersKubeadmConfigTemplate,
+			m.createWorkersAzureMachineTemplate,
+			m.createWorkersMachineDeployment,
+		}
+
+		for _, step := range steps {
+			if err := step(ctx); err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *azureMigrator) Cutover(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/giantswarm/microerror"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	capz "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MigrationPreflightFailed is set on the CAPI Cluster when Validate finds one
+// or more problems with the rendered migration plan. Its message lists every
+// failure found in one pass instead of surfacing them one reconcile loop at
+// a time.
+const MigrationPreflightFailed capi.ConditionType = "MigrationPreflightFailed"
+
+// Validate checks, in one pass, the inputs createKubeadmControlPlane and
+// createEncryptionConfigSecret look up: the VNET CIDR and master IP, the
+// base domain in the API endpoint, the Release, and the Vault encryption
+// keys. It reports every failure found at once instead of one reconcile loop
+// at a time. It does not render the templates or build the CAPI resources,
+// so problems that only show up while rendering are not caught here.
+func (m *azureMigrator) Validate(ctx context.Context) error {
+	var result *multierror.Error
+
+	cluster, err := m.cluster()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	azureCluster, err := m.azureCluster()
+	if err != nil {
+		result = multierror.Append(result, err)
+	} else {
+		if vnet, err := m.getVNETCIDR(azureCluster); err != nil {
+			result = multierror.Append(result, err)
+		} else if err := validateMasterIPFreeInVNET(vnet); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		if _, err := getInstallationBaseDomainFromAPIEndpoint(azureCluster.Spec.ControlPlaneEndpoint.Host); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		releaseVersion := azureCluster.GetLabels()["release.giantswarm.io/version"]
+		if _, err := m.getReleaseComponents(ctx, releaseVersion); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if _, err := m.readEncryptionKeysFromVault(ctx); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if result == nil || result.Len() == 0 {
+		conditions.MarkFalse(cluster, MigrationPreflightFailed, "PreflightChecksPassed", capi.ConditionSeverityInfo, "all preflight checks passed")
+		if err := m.mcCtrlClient.Status().Update(ctx, cluster); err != nil {
+			return microerror.Mask(err)
+		}
+		return nil
+	}
+
+	conditions.Set(cluster, &capi.Condition{
+		Type:     MigrationPreflightFailed,
+		Status:   corev1.ConditionTrue,
+		Reason:   "PreflightChecksFailed",
+		Severity: capi.ConditionSeverityError,
+		Message:  result.Error(),
+	})
+
+	if err := m.mcCtrlClient.Status().Update(ctx, cluster); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return microerror.Mask(result)
+}
+
+func (m *azureMigrator) azureCluster() (*capz.AzureCluster, error) {
+	obj, exists := m.crs["AzureCluster"]
+	if !exists {
+		return nil, microerror.Mask(fmt.Errorf("AzureCluster not read yet for %q", m.clusterID))
+	}
+
+	azureCluster, ok := obj.(*capz.AzureCluster)
+	if !ok {
+		return nil, microerror.Mask(fmt.Errorf("can't convert obj (%T) to %T", obj, azureCluster))
+	}
+
+	return azureCluster, nil
+}
+
+// validateMasterIPFreeInVNET checks that the master IP getMasterIPForVNet
+// would hand out actually falls inside the VNET and isn't the network
+// address itself.
+func validateMasterIPFreeInVNET(vnet *net.IPNet) error {
+	masterIP := getMasterIPForVNet(vnet)
+
+	if !vnet.Contains(masterIP) {
+		return microerror.Mask(fmt.Errorf("master IP %s is not contained in VNET CIDR %s", masterIP, vnet))
+	}
+
+	if masterIP.Equal(vnet.IP) {
+		return microerror.Mask(fmt.Errorf("master IP %s collides with the VNET network address", masterIP))
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"net"
+	"testing"
+)
+
+func TestValidateMasterIPFreeInVNET(t *testing.T) {
+	testCases := []struct {
+		name    string
+		vnet    *net.IPNet
+		wantErr bool
+	}{
+		{
+			name:    "master IP fits inside a /24",
+			vnet:    &net.IPNet{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(24, 32)},
+			wantErr: false,
+		},
+		{
+			name:    "master IP falls outside a small VNET",
+			vnet:    &net.IPNet{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(30, 32)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateMasterIPFreeInVNET(tc.vnet)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+		})
+	}
+}